@@ -0,0 +1,311 @@
+/**
+A compact CSS-like grammar for Selector, so tests can copy-paste a selector
+string instead of building a map[string]interface{} literal by hand.
+
+Supported forms:
+
+	android.widget.Button      -> {"className": "android.widget.Button"}
+	#id                        -> {"resourceId": "id"}
+	[text=value]               -> {"text": "value"}
+	[desc*=value]              -> {"descriptionContains": "value"}
+	[text^=value]              -> {"textStartsWith": "value"}
+	[text~=/regex/]            -> {"textMatches": "regex"}
+	:checked :enabled :focused
+	:selected :clickable       -> boolean mask keys
+	:nth(N)                    -> {"instance": N}
+	a b, a > b                 -> b as a Child of a
+	a + b                      -> b as a Sibling of a
+
+https://github.com/openatx/uiautomator2#selector
+*/
+package uiautomator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// attrAlias maps the short attribute names used in the CSS grammar to the
+// selector field they configure.
+var attrAlias = map[string]string{
+	"text":        "text",
+	"desc":        "description",
+	"description": "description",
+	"package":     "packageName",
+	"packageName": "packageName",
+	"class":       "className",
+	"className":   "className",
+	"id":          "resourceId",
+	"resourceId":  "resourceId",
+	"index":       "index",
+	"instance":    "instance",
+}
+
+// pseudoMask maps the boolean pseudo-classes to the selector field they set.
+var pseudoMask = map[string]string{
+	"checked":   "checked",
+	"enabled":   "enabled",
+	"focused":   "focused",
+	"selected":  "selected",
+	"clickable": "clickable",
+}
+
+type cssSegment struct {
+	combinator string // "" for the root segment, "child" or "sibling" otherwise
+	selector   Selector
+}
+
+type cssParser struct {
+	input string
+	pos   int
+}
+
+func (p *cssParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid css selector at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *cssParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *cssParser) peek() byte {
+	return p.input[p.pos]
+}
+
+func (p *cssParser) skipSpaces() {
+	for !p.eof() && p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+// takeUntil consumes bytes up to (but excluding) the first occurrence of any
+// byte in stop, or the end of the input.
+func (p *cssParser) takeUntil(stop string) string {
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(stop, rune(p.peek())) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func parseCSSSelector(sel string) ([]cssSegment, error) {
+	p := &cssParser{input: sel}
+
+	var segments []cssSegment
+	combinator := ""
+	for {
+		p.skipSpaces()
+		if p.eof() {
+			return nil, p.errorf("unexpected end of selector")
+		}
+
+		segSelector, err := p.parseCompound()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, cssSegment{combinator: combinator, selector: segSelector})
+
+		p.skipSpaces()
+		if p.eof() {
+			break
+		}
+
+		switch p.peek() {
+		case '>':
+			p.pos++
+			combinator = "child"
+		case '+':
+			p.pos++
+			combinator = "sibling"
+		default:
+			// Bare whitespace between compounds also means "child".
+			combinator = "child"
+		}
+	}
+
+	return segments, nil
+}
+
+// parseCompound parses a single simple selector: an optional type name
+// followed by any number of #id, [attr] and :pseudo clauses.
+func (p *cssParser) parseCompound() (Selector, error) {
+	selector := Selector{}
+
+	typeName := p.takeUntil("#[: >+")
+	if typeName != "" {
+		selector["className"] = typeName
+	}
+
+	for !p.eof() {
+		switch p.peek() {
+		case '#':
+			p.pos++
+			id := p.takeUntil("#[: >+")
+			if id == "" {
+				return nil, p.errorf("expected an id after '#'")
+			}
+			selector["resourceId"] = id
+		case '[':
+			if err := p.parseAttr(selector); err != nil {
+				return nil, err
+			}
+		case ':':
+			if err := p.parsePseudo(selector); err != nil {
+				return nil, err
+			}
+		default:
+			if len(selector) == 0 {
+				return nil, p.errorf("expected a selector, found nothing")
+			}
+			return selector, nil
+		}
+	}
+
+	if len(selector) == 0 {
+		return nil, p.errorf("expected a selector, found nothing")
+	}
+	return selector, nil
+}
+
+func (p *cssParser) parseAttr(selector Selector) error {
+	start := p.pos
+	p.pos++ // consume '['
+
+	body := p.takeUntil("]")
+	if p.eof() {
+		p.pos = start
+		return p.errorf("unterminated '['")
+	}
+	p.pos++ // consume ']'
+
+	op, attr, value, err := splitAttrBody(body)
+	if err != nil {
+		p.pos = start
+		return p.errorf("%s", err)
+	}
+
+	key, ok := attrAlias[attr]
+	if !ok {
+		p.pos = start
+		return p.errorf("unknown attribute %q", attr)
+	}
+
+	switch op {
+	case "=":
+		if key == "index" || key == "instance" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				p.pos = start
+				return p.errorf("%s must be numeric, got %q", key, value)
+			}
+			selector[key] = n
+		} else {
+			selector[key] = value
+		}
+	case "*=":
+		selector[key+"Contains"] = value
+	case "^=":
+		selector[key+"StartsWith"] = value
+	case "~=":
+		selector[key+"Matches"] = strings.Trim(value, "/")
+	default:
+		p.pos = start
+		return p.errorf("unsupported operator %q", op)
+	}
+
+	return nil
+}
+
+// splitAttrBody splits the inside of an [attr<op>value] clause into its
+// operator, attribute name and value.
+func splitAttrBody(body string) (op, attr, value string, err error) {
+	for _, candidate := range []string{"*=", "^=", "~=", "="} {
+		if idx := strings.Index(body, candidate); idx >= 0 {
+			return candidate, body[:idx], body[idx+len(candidate):], nil
+		}
+	}
+	return "", "", "", fmt.Errorf("expected an operator in %q", body)
+}
+
+func (p *cssParser) parsePseudo(selector Selector) error {
+	start := p.pos
+	p.pos++ // consume ':'
+
+	name := p.takeUntil("([# >+")
+	if name == "nth" {
+		if p.eof() || p.peek() != '(' {
+			p.pos = start
+			return p.errorf("expected '(' after :nth")
+		}
+		p.pos++
+		arg := p.takeUntil(")")
+		if p.eof() {
+			p.pos = start
+			return p.errorf("unterminated ':nth('")
+		}
+		p.pos++ // consume ')'
+
+		n, err := strconv.Atoi(strings.TrimSpace(arg))
+		if err != nil {
+			p.pos = start
+			return p.errorf("the :nth() argument must be numeric, got %q", arg)
+		}
+		selector["instance"] = n
+		return nil
+	}
+
+	key, ok := pseudoMask[name]
+	if !ok {
+		p.pos = start
+		return p.errorf("unknown pseudo-class %q", name)
+	}
+	selector[key] = true
+	return nil
+}
+
+// applyCSSSegments chains every non-root segment onto ele's selector, in
+// order. It appends each step with appendDescentStep directly rather than
+// calling Element.Child/Sibling in a loop: those overwrite a single
+// selector's childOrSibling/childOrSiblingSelector entries in place, so
+// calling them repeatedly on the same Element only keeps the last segment
+// and silently drops every combinator in between (e.g. "a b c" would resolve
+// to "a with child c", discarding b).
+func applyCSSSegments(ele *Element, segments []cssSegment) (*Element, error) {
+	for _, seg := range segments {
+		if err := appendDescentStep(ele.selector, seg.combinator == "sibling", seg.selector); err != nil {
+			return nil, err
+		}
+	}
+	return ele, nil
+}
+
+/*
+Query the UI element with a compact CSS-like selector string
+*/
+func (ua *UIAutomator) GetElementByCSS(sel string) (*Element, error) {
+	segments, err := parseCSSSelector(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	ele, err := ua.GetElementBySelector(segments[0].selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyCSSSegments(ele, segments[1:])
+}
+
+/*
+Find a descendant (or sibling) of the element with a compact CSS-like
+selector string
+*/
+func (ele *Element) FindCSS(sel string) (*Element, error) {
+	segments, err := parseCSSSelector(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyCSSSegments(ele, segments)
+}
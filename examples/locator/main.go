@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	ug "uiautomator"
+)
+
+func main() {
+	client := ug.New(&ug.Config{
+		Host:      "10.10.20.78",
+		Port:      7912,
+		AutoRetry: 0,
+		Timeout:   10,
+	})
+
+	// Locator is the recommended way to query elements: it's immutable, so
+	// it can be branched and reused without the aliasing surprises of
+	// sharing a single *Element across multiple queries.
+	urlBar := client.Locator(
+		map[string]interface{}{
+			"resourceId": "com.android.chrome:id/url_bar",
+		},
+	)
+
+	if err := urlBar.ClearText(); err != nil {
+		panic(err)
+	}
+
+	if err := urlBar.SetText("https://www.google.com/"); err != nil {
+		panic(err)
+	}
+
+	// Branching from urlBar never mutates it, so it's still safe to use
+	// after this.
+	clock := urlBar.Child(
+		map[string]interface{}{
+			"className": "android.widget.FrameLayout",
+		},
+	).Nth(0)
+
+	text, err := clock.GetText()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(text)
+}
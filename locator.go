@@ -0,0 +1,311 @@
+/**
+Locator is an immutable, lazily-resolved alternative to Element. A *Element
+returned from GetElementBySelector mutates its own selector in place on
+Child/Sibling/Eq, so a single value cannot safely be reused across branching
+queries. Locator instead keeps its selector chain as data; every derivation
+(Child, Sibling, Nth, Filter) returns a new *Locator, and the chain is only
+turned into a concrete *Element when an action or query actually needs one.
+*/
+package uiautomator
+
+// locatorStep carries its own index, set by Nth() when it was current, so a
+// later Child/Sibling call doesn't shift which level the index applies to.
+type locatorStep struct {
+	sibling  bool
+	selector Selector
+	index    *int
+}
+
+type Locator struct {
+	ua        *UIAutomator
+	root      Selector
+	rootIndex *int
+	steps     []locatorStep
+	filter    func(ElementInfo) bool
+}
+
+/*
+Build a Locator for selector, without touching the device
+*/
+func (ua *UIAutomator) Locator(selector Selector) *Locator {
+	return &Locator{ua: ua, root: selector}
+}
+
+// clone makes a shallow copy of l with its own steps backing array, so that
+// branching from the same Locator never lets one branch see another's
+// appended steps.
+func (l *Locator) clone() *Locator {
+	steps := make([]locatorStep, len(l.steps))
+	copy(steps, l.steps)
+
+	next := &Locator{ua: l.ua, root: l.root, steps: steps, filter: l.filter}
+	if l.rootIndex != nil {
+		index := *l.rootIndex
+		next.rootIndex = &index
+	}
+	return next
+}
+
+// withoutCurrentIndex clears whatever index is bound to the current
+// (deepest) position, without touching indices bound to earlier levels.
+// All/Count use this to enumerate every match at the current level.
+func (l *Locator) withoutCurrentIndex() *Locator {
+	next := l.clone()
+	if len(next.steps) == 0 {
+		next.rootIndex = nil
+	} else {
+		next.steps[len(next.steps)-1].index = nil
+	}
+	return next
+}
+
+func withInstance(selector Selector, instance int) Selector {
+	next := Selector{}
+	for k, v := range selector {
+		next[k] = v
+	}
+	next["instance"] = instance
+	return next
+}
+
+/*
+Narrow to children matching selector
+*/
+func (l *Locator) Child(selector Selector) *Locator {
+	next := l.clone()
+	next.steps = append(next.steps, locatorStep{selector: selector})
+	return next
+}
+
+/*
+Narrow to siblings matching selector
+*/
+func (l *Locator) Sibling(selector Selector) *Locator {
+	next := l.clone()
+	next.steps = append(next.steps, locatorStep{sibling: true, selector: selector})
+	return next
+}
+
+/*
+Narrow to the Nth match (0-based) at the current position in the chain.
+Nth binds to whatever Child/Sibling call was last (or the root selector if
+none yet), so later Child/Sibling calls descend from that specific match
+instead of shifting which level the index applies to:
+
+	row := list.Child(rowSel).Nth(1)   // the 2nd row
+	btn := row.Child(deleteSel)        // the delete button within that row
+*/
+func (l *Locator) Nth(index int) *Locator {
+	next := l.clone()
+	idx := index
+	if len(next.steps) == 0 {
+		next.rootIndex = &idx
+	} else {
+		next.steps[len(next.steps)-1].index = &idx
+	}
+	return next
+}
+
+/*
+Narrow to matches for which fn returns true, evaluated via GetInfo
+*/
+func (l *Locator) Filter(fn func(ElementInfo) bool) *Locator {
+	next := l.clone()
+	next.filter = fn
+	return next
+}
+
+// resolve turns the selector chain into a concrete *Element, freshly built
+// so the caller owns an Element nothing else can mutate.
+//
+// It builds the chained selector directly with appendDescentStep rather than
+// delegating to Element.Child/Sibling: those mutate a *single* selector's
+// childOrSibling/childOrSiblingSelector entries in place, so calling them in
+// a loop on the same Element only ever keeps the last step and silently
+// drops every level in between.
+func (l *Locator) resolve() (*Element, error) {
+	root := l.root
+	if l.rootIndex != nil {
+		root = withInstance(root, *l.rootIndex)
+	}
+
+	selector, err := parseSelector(root)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range l.steps {
+		stepSelector := step.selector
+		if step.index != nil {
+			stepSelector = withInstance(stepSelector, *step.index)
+		}
+		if err := appendDescentStep(selector, step.sibling, stepSelector); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Element{ua: l.ua, original: root, selector: selector}, nil
+}
+
+/*
+Count how many elements currently match the selector chain (the index bound
+to the current position by Nth, if any, is ignored; indices bound to
+earlier levels and Filter are both honored)
+*/
+func (l *Locator) Count() (int, error) {
+	if l.filter != nil {
+		elements, err := l.All()
+		if err != nil {
+			return 0, err
+		}
+		return len(elements), nil
+	}
+
+	base := l.withoutCurrentIndex()
+	ele, err := base.resolve()
+	if err != nil {
+		return 0, err
+	}
+	return ele.Count()
+}
+
+/*
+Resolve every element currently matching the selector chain
+*/
+func (l *Locator) All() ([]*Element, error) {
+	base := l.withoutCurrentIndex()
+
+	ele, err := base.resolve()
+	if err != nil {
+		return nil, err
+	}
+	count, err := ele.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]*Element, 0, count)
+	for i := 0; i < count; i++ {
+		match, err := base.Nth(i).resolve()
+		if err != nil {
+			return nil, err
+		}
+		if l.filter != nil {
+			info, err := match.GetInfo()
+			if err != nil {
+				return nil, err
+			}
+			if !l.filter(*info) {
+				continue
+			}
+		}
+		elements = append(elements, match)
+	}
+
+	return elements, nil
+}
+
+/*
+Resolve the first element currently matching the selector chain
+*/
+func (l *Locator) First() (*Element, error) {
+	elements, err := l.All()
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) == 0 {
+		return nil, &UiaError{Code: -32002, Message: "Element not found"}
+	}
+	return elements[0], nil
+}
+
+/*
+Resolve the last element currently matching the selector chain
+*/
+func (l *Locator) Last() (*Element, error) {
+	elements, err := l.All()
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) == 0 {
+		return nil, &UiaError{Code: -32002, Message: "Element not found"}
+	}
+	return elements[len(elements)-1], nil
+}
+
+// resolveForAction is what every action method resolves through. A plain
+// resolve() only ever sees the raw selector chain, so a Locator built with
+// Filter would silently act on an unfiltered match; routing through First()
+// instead makes the filter apply before the action runs.
+func (l *Locator) resolveForAction() (*Element, error) {
+	if l.filter != nil {
+		return l.First()
+	}
+	return l.resolve()
+}
+
+/*
+Click on the element, resolving the selector chain first
+*/
+func (l *Locator) Click(offset *Position) error {
+	ele, err := l.resolveForAction()
+	if err != nil {
+		return err
+	}
+	return ele.Click(offset)
+}
+
+/*
+Long click on the element, resolving the selector chain first
+*/
+func (l *Locator) LongClick() error {
+	ele, err := l.resolveForAction()
+	if err != nil {
+		return err
+	}
+	return ele.LongClick()
+}
+
+/*
+Get widget text, resolving the selector chain first
+*/
+func (l *Locator) GetText() (string, error) {
+	ele, err := l.resolveForAction()
+	if err != nil {
+		return "", err
+	}
+	return ele.GetText()
+}
+
+/*
+Set widget text, resolving the selector chain first
+*/
+func (l *Locator) SetText(text string) error {
+	ele, err := l.resolveForAction()
+	if err != nil {
+		return err
+	}
+	return ele.SetText(text)
+}
+
+/*
+Clear the widget text, resolving the selector chain first
+*/
+func (l *Locator) ClearText() error {
+	ele, err := l.resolveForAction()
+	if err != nil {
+		return err
+	}
+	return ele.ClearText()
+}
+
+/*
+Check if the specific UI object exists, resolving the selector chain first
+*/
+func (l *Locator) WaitForExists() error {
+	ele, err := l.resolveForAction()
+	if err != nil {
+		return err
+	}
+	return ele.WaitForExists()
+}
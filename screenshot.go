@@ -0,0 +1,140 @@
+/**
+Element screenshots, cropped out of a full-device capture so callers get a
+visual regression or OCR input for a single widget without duplicating the
+crop math that already lives in GetRect/Center.
+*/
+package uiautomator
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+)
+
+/*
+Capture a full-device screenshot as PNG-encoded bytes
+*/
+func (ua *UIAutomator) Screenshot() ([]byte, error) {
+	// Go through the client's own configured transport, like every other
+	// request this package makes, instead of http.DefaultClient: otherwise
+	// this call ignores Config.Timeout/AutoRetry and can block forever
+	// against a slow or unreachable device.
+	resp, err := ua.client.Get(ua.url("/screenshot/0"))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &UiaError{
+			Code:    resp.StatusCode,
+			Message: "failed to capture screenshot",
+		}
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// visibleRect returns VisibleBounds when the element reports one, falling
+// back to Bounds otherwise.
+func (ele Element) visibleRect() (*ElementRect, error) {
+	info, err := ele.GetInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if info.VisibleBounds != nil {
+		return info.VisibleBounds, nil
+	}
+	return info.Bounds, nil
+}
+
+// croppedImage captures a full-device screenshot and crops it to the
+// element's visible rect.
+func (ele Element) croppedImage() (image.Image, error) {
+	if err := ele.WaitForExists(); err != nil {
+		return nil, err
+	}
+
+	rect, err := ele.visibleRect()
+	if err != nil {
+		return nil, err
+	}
+	if rect == nil {
+		return nil, &UiaError{Code: -32002, Message: "Element not found"}
+	}
+
+	raw, err := ele.ua.Screenshot()
+	if err != nil {
+		return nil, err
+	}
+
+	full, err := png.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := image.Rect(0, 0, rect.Right-rect.Left, rect.Bottom-rect.Top)
+	cropped := image.NewRGBA(bounds)
+	draw.Draw(cropped, bounds, full, image.Pt(rect.Left, rect.Top), draw.Src)
+
+	return cropped, nil
+}
+
+/*
+Capture a screenshot of the element, cropped to its visible bounds
+*/
+func (ele Element) Screenshot() ([]byte, error) {
+	img, err := ele.croppedImage()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+/*
+Capture a screenshot of the element and write it to path as PNG
+*/
+func (ele Element) ScreenshotToFile(path string) error {
+	data, err := ele.Screenshot()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+/*
+Read the color of a single pixel within the element, offset is a fraction
+of the element's width/height measured from its top-left corner, the same
+convention Center uses
+*/
+func (ele Element) PixelAt(offset Position) (color.RGBA, error) {
+	img, err := ele.croppedImage()
+	if err != nil {
+		return color.RGBA{}, err
+	}
+
+	bounds := img.Bounds()
+	x := bounds.Min.X + int(float32(bounds.Dx())*offset.X)
+	y := bounds.Min.Y + int(float32(bounds.Dy())*offset.Y)
+
+	r, g, b, a := img.At(x, y).RGBA()
+	return color.RGBA{
+		R: uint8(r >> 8),
+		G: uint8(g >> 8),
+		B: uint8(b >> 8),
+		A: uint8(a >> 8),
+	}, nil
+}
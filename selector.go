@@ -18,6 +18,7 @@ type (
 		position *Position
 		selector Selector
 		original Selector
+		waitOpts *ElementWaitOptions
 	}
 
 	ElementRect struct {
@@ -176,36 +177,10 @@ func (ele *Element) Eq(index int) (*Element, error) {
 }
 
 /*
-Check if the specific UI object exists
+Check if the specific UI object exists, using the default wait options
 */
 func (ele Element) WaitForExists() error {
-	var RPCReturned struct {
-		Result bool `json:"result"`
-	}
-	transform := func(response *http.Response) error {
-		err := json.NewDecoder(response.Body).Decode(&RPCReturned)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-
-	err := ele.ua.post(
-		&RPCOptions{
-			Method: "waitForExists",
-			Params: []interface{}{getParams(ele.selector), 20000},
-		},
-		nil,
-		transform,
-	)
-	if err != nil || RPCReturned.Result == false {
-		return &UiaError{
-			Code:    -32002,
-			Message: "Element not found",
-		}
-	}
-
-	return nil
+	return ele.WaitForExistsWithOptions(ElementWaitOptions{})
 }
 
 /*
@@ -483,6 +458,26 @@ func parseSelector(selector Selector) (Selector, error) {
 	return res, nil
 }
 
+// appendDescentStep parses next and appends it to selector's existing
+// childOrSibling/childOrSiblingSelector chain (already initialized by
+// parseSelector), instead of overwriting it. This lets a selector record a
+// multi-level chain of child/sibling steps rather than only the last one.
+func appendDescentStep(selector Selector, sibling bool, next Selector) error {
+	parsedNext, err := parseSelector(next)
+	if err != nil {
+		return err
+	}
+
+	kind := "child"
+	if sibling {
+		kind = "sibling"
+	}
+
+	selector["childOrSibling"] = append(selector["childOrSibling"].([]interface{}), kind)
+	selector["childOrSiblingSelector"] = append(selector["childOrSiblingSelector"].([]interface{}), parsedNext)
+	return nil
+}
+
 func getParams(selector Selector) interface{} {
 	if uid, ok := selector["__UID"]; ok {
 		return uid
@@ -0,0 +1,163 @@
+/**
+State predicates for Element, modelled after the assertion helpers found in
+modern browser-automation frameworks. Unlike most of the Element API these
+never wait and never return a "not found" error — a missing widget is just
+reported as a negative result so callers can use them as guards.
+*/
+package uiautomator
+
+// isNotFoundErr reports whether err is the sentinel uiautomator2 raises when
+// the underlying selector matches nothing.
+func isNotFoundErr(err error) bool {
+	uiaErr, ok := err.(*UiaError)
+	return ok && uiaErr.Code == -32002
+}
+
+// stateInfo fetches the element info, swallowing "not found" errors so the
+// Is* predicates can use it as a safe existence guard.
+func (ele Element) stateInfo() (*ElementInfo, error) {
+	info, err := ele.GetInfo()
+	if err != nil {
+		if isNotFoundErr(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return info, nil
+}
+
+/*
+Check if the element is currently present and rendered
+*/
+func (ele Element) IsVisible() (bool, error) {
+	info, err := ele.stateInfo()
+	if err != nil {
+		return false, err
+	}
+	return info != nil, nil
+}
+
+/*
+Check if the element is absent or not rendered
+*/
+func (ele Element) IsHidden() (bool, error) {
+	visible, err := ele.IsVisible()
+	if err != nil {
+		return false, err
+	}
+	return !visible, nil
+}
+
+/*
+Check if the element is enabled
+*/
+func (ele Element) IsEnabled() (bool, error) {
+	info, err := ele.stateInfo()
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.Enabled, nil
+}
+
+/*
+Check if the element is disabled
+*/
+func (ele Element) IsDisabled() (bool, error) {
+	enabled, err := ele.IsEnabled()
+	if err != nil {
+		return false, err
+	}
+	return !enabled, nil
+}
+
+/*
+Check if the element is checked
+*/
+func (ele Element) IsChecked() (bool, error) {
+	info, err := ele.stateInfo()
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.Checked, nil
+}
+
+/*
+Check if the element is selected
+*/
+func (ele Element) IsSelected() (bool, error) {
+	info, err := ele.stateInfo()
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.Selected, nil
+}
+
+/*
+Check if the element currently has focus
+*/
+func (ele Element) IsFocused() (bool, error) {
+	info, err := ele.stateInfo()
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.Focused, nil
+}
+
+/*
+Check if the element is clickable
+*/
+func (ele Element) IsClickable() (bool, error) {
+	info, err := ele.stateInfo()
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.Clickable, nil
+}
+
+/*
+Check if the element is long-clickable
+*/
+func (ele Element) IsLongClickable() (bool, error) {
+	info, err := ele.stateInfo()
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.LongClickable, nil
+}
+
+/*
+Check if the element is scrollable
+*/
+func (ele Element) IsScrollable() (bool, error) {
+	info, err := ele.stateInfo()
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.Scrollable, nil
+}
+
+/*
+Check if the element is checkable
+*/
+func (ele Element) IsCheckable() (bool, error) {
+	info, err := ele.stateInfo()
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.Checkable, nil
+}
+
+/*
+Check if the element accepts text input
+
+uiautomator2 does not expose an "editable" flag directly, so this is
+approximated as an enabled, focusable widget, which is how Android marks
+text fields.
+*/
+func (ele Element) IsEditable() (bool, error) {
+	info, err := ele.stateInfo()
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.Enabled && info.Focusable, nil
+}
@@ -0,0 +1,136 @@
+/**
+Wait configuration for Element. WaitForExists used to hardcode a 20s
+server-side timeout with no way to tune the polling cadence or bound the
+implicit wait inside Click/SetText/GetText/swipe. ElementWaitOptions and the
+With builder let callers override either per call or for every action taken
+on a given Element.
+*/
+package uiautomator
+
+import "time"
+
+type ElementWaitOptions struct {
+	Timeout      time.Duration
+	PollInterval time.Duration
+}
+
+const (
+	defaultElementTimeout = 20 * time.Second
+	defaultPollInterval   = 200 * time.Millisecond
+)
+
+// resolveWaitOptions layers opts over the Element's own default (set via
+// With), the client-wide Config.DefaultElementTimeout, and finally the
+// package default, in that order.
+func (ele Element) resolveWaitOptions(opts *ElementWaitOptions) ElementWaitOptions {
+	var resolved ElementWaitOptions
+	if ele.waitOpts != nil {
+		resolved = *ele.waitOpts
+	}
+
+	if opts != nil {
+		if opts.Timeout > 0 {
+			resolved.Timeout = opts.Timeout
+		}
+		if opts.PollInterval > 0 {
+			resolved.PollInterval = opts.PollInterval
+		}
+	}
+
+	if resolved.Timeout <= 0 {
+		resolved.Timeout = defaultElementTimeout
+		if ele.ua != nil && ele.ua.config != nil && ele.ua.config.DefaultElementTimeout > 0 {
+			resolved.Timeout = ele.ua.config.DefaultElementTimeout
+		}
+	}
+	if resolved.PollInterval <= 0 {
+		resolved.PollInterval = defaultPollInterval
+	}
+
+	return resolved
+}
+
+/*
+Check if the specific UI object exists, polling at opts.PollInterval until
+opts.Timeout elapses
+
+A transient error from the underlying RPC call (e.g. a network hiccup) does
+not abort the wait early; it is retried like any other not-yet-satisfied
+poll, and only surfaced if it's still the last outcome once opts.Timeout
+elapses.
+*/
+func (ele Element) WaitForExistsWithOptions(opts ElementWaitOptions) error {
+	resolved := ele.resolveWaitOptions(&opts)
+	deadline := time.Now().Add(resolved.Timeout)
+
+	var lastErr error
+	for {
+		visible, err := ele.IsVisible()
+		switch {
+		case err != nil:
+			lastErr = err
+		case visible:
+			return nil
+		default:
+			lastErr = nil
+		}
+
+		if time.Now().After(deadline) {
+			if lastErr != nil {
+				return lastErr
+			}
+			return &UiaError{
+				Code:    -32002,
+				Message: "Element not found",
+			}
+		}
+		time.Sleep(resolved.PollInterval)
+	}
+}
+
+/*
+Wait until the specific UI object is gone, polling at opts.PollInterval
+until opts.Timeout elapses
+
+As with WaitForExistsWithOptions, a transient RPC error is retried rather
+than aborting the wait, and only surfaced if it's still the last outcome
+once opts.Timeout elapses.
+*/
+func (ele Element) WaitUntilGone(opts ElementWaitOptions) error {
+	resolved := ele.resolveWaitOptions(&opts)
+	deadline := time.Now().Add(resolved.Timeout)
+
+	var lastErr error
+	for {
+		hidden, err := ele.IsHidden()
+		switch {
+		case err != nil:
+			lastErr = err
+		case hidden:
+			return nil
+		default:
+			lastErr = nil
+		}
+
+		if time.Now().After(deadline) {
+			if lastErr != nil {
+				return lastErr
+			}
+			return &UiaError{
+				Code:    -32003,
+				Message: "Element still present",
+			}
+		}
+		time.Sleep(resolved.PollInterval)
+	}
+}
+
+/*
+Return a copy of the Element bound to opts, so Click/SetText/GetText/swipe
+and the wait helpers use it as their default instead of the package default
+*/
+func (ele Element) With(opts ElementWaitOptions) *Element {
+	bound := ele
+	bound.waitOpts = &opts
+	return &bound
+}
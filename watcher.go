@@ -0,0 +1,207 @@
+/**
+Watchers mirror the uiautomator2 concept of the same name: named rules that
+fire an action whenever their selectors all match the current screen. They
+run on a background goroutine so long test flows don't need to poll for
+permission dialogs, ads, or other interstitial UI themselves.
+*/
+package uiautomator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	// WatcherAction is what a Watcher runs once its rule matches.
+	WatcherAction interface {
+		apply(ua *UIAutomator) error
+	}
+
+	// ClickAction clicks the first element matching Selector.
+	ClickAction struct {
+		Selector Selector
+	}
+
+	// PressAction presses a hardware/navigation key, e.g. "back" or "home".
+	PressAction struct {
+		Key string
+	}
+
+	// FuncAction runs an arbitrary callback.
+	FuncAction struct {
+		Fn func(*UIAutomator) error
+	}
+
+	watcherRule struct {
+		when  []Selector
+		then  WatcherAction
+		fired bool
+	}
+
+	// WatcherManager owns the registered rules and the background goroutine
+	// that evaluates them.
+	WatcherManager struct {
+		ua    *UIAutomator
+		mu    sync.Mutex
+		rules map[string]*watcherRule
+		stop  chan struct{}
+	}
+)
+
+// watcherManagers caches the one WatcherManager per client, so repeated
+// calls to Watchers() operate on the same registered rules instead of each
+// handing back an empty manager.
+var (
+	watcherManagers   = map[*UIAutomator]*WatcherManager{}
+	watcherManagersMu sync.Mutex
+)
+
+func (a ClickAction) apply(ua *UIAutomator) error {
+	ele, err := ua.GetElementBySelector(a.Selector)
+	if err != nil {
+		return err
+	}
+	return ele.Click(nil)
+}
+
+func (a PressAction) apply(ua *UIAutomator) error {
+	return ua.Press(a.Key)
+}
+
+func (a FuncAction) apply(ua *UIAutomator) error {
+	return a.Fn(ua)
+}
+
+/*
+Get the Watcher manager for this client, creating it on first use so later
+calls return the same instance and its registered rules
+*/
+func (ua *UIAutomator) Watchers() *WatcherManager {
+	watcherManagersMu.Lock()
+	defer watcherManagersMu.Unlock()
+
+	if m, ok := watcherManagers[ua]; ok {
+		return m
+	}
+
+	m := &WatcherManager{ua: ua, rules: map[string]*watcherRule{}}
+	watcherManagers[ua] = m
+	return m
+}
+
+/*
+Register a named watcher rule. It fires then once every selector in when
+matches the current screen.
+*/
+func (m *WatcherManager) Register(name string, when []Selector, then WatcherAction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.rules[name]; exists {
+		return fmt.Errorf("watcher %q is already registered", name)
+	}
+	m.rules[name] = &watcherRule{when: when, then: then}
+	return nil
+}
+
+/*
+Remove a previously registered watcher
+*/
+func (m *WatcherManager) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.rules, name)
+}
+
+/*
+Check whether a watcher has fired since it was registered
+*/
+func (m *WatcherManager) Triggered(name string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rule, ok := m.rules[name]
+	if !ok {
+		return false, fmt.Errorf("watcher %q is not registered", name)
+	}
+	return rule.fired, nil
+}
+
+/*
+Start evaluating the registered watchers every interval, on a background
+goroutine, until Stop is called
+*/
+func (m *WatcherManager) Start(interval time.Duration) {
+	m.mu.Lock()
+	if m.stop != nil {
+		m.mu.Unlock()
+		return
+	}
+	m.stop = make(chan struct{})
+	stop := m.stop
+	m.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				m.check()
+			}
+		}
+	}()
+}
+
+/*
+Stop the background watcher goroutine
+*/
+func (m *WatcherManager) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stop == nil {
+		return
+	}
+	close(m.stop)
+	m.stop = nil
+}
+
+// check evaluates every rule once. It holds the manager's mutex for the
+// whole pass so a foreground call to Triggered/Register never observes a
+// rule mid-fire, and so two ticks never run concurrently.
+func (m *WatcherManager) check() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, rule := range m.rules {
+		matched, err := m.matches(rule.when)
+		if err != nil || !matched {
+			continue
+		}
+		rule.fired = true
+		rule.then.apply(m.ua)
+	}
+}
+
+func (m *WatcherManager) matches(when []Selector) (bool, error) {
+	for _, selector := range when {
+		ele, err := m.ua.GetElementBySelector(selector)
+		if err != nil {
+			return false, err
+		}
+
+		visible, err := ele.IsVisible()
+		if err != nil {
+			return false, err
+		}
+		if !visible {
+			return false, nil
+		}
+	}
+	return true, nil
+}